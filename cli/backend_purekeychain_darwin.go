@@ -0,0 +1,8 @@
+//go:build darwin
+
+package cli
+
+// pureKeychainBackendName is the --backend value for the CGO-free macOS keychain
+// implementation in vault.NewPureKeychainKeyring. It's only offered on darwin, where
+// it's a usable alternative to "keychain".
+const pureKeychainBackendName = "pure-keychain"
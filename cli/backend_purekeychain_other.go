@@ -0,0 +1,7 @@
+//go:build !darwin
+
+package cli
+
+// pureKeychainBackendName is empty on non-darwin platforms, where the pure-keychain
+// backend doesn't apply.
+const pureKeychainBackendName = ""
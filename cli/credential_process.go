@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/99designs/aws-vault/v7/vault"
+	"github.com/99designs/keyring"
+	"github.com/alecthomas/kingpin/v2"
+)
+
+type CredentialProcessCommandInput struct {
+	ProfileName string
+	MfaToken    string
+	NoSession   bool
+}
+
+// credentialProcessOutput matches the JSON schema expected by the AWS SDKs
+// when a profile's `credential_process` setting points at this command.
+type credentialProcessOutput struct {
+	Version         int
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string `json:",omitempty"`
+	Expiration      string `json:",omitempty"`
+}
+
+func ConfigureCredentialProcessCommand(app *kingpin.Application, a *AwsVault) {
+	input := CredentialProcessCommandInput{}
+
+	cmd := app.Command("credential-process", "Print credentials in the format expected by the AWS SDK credential_process option")
+
+	cmd.Arg("profile", "Name of the profile").
+		Required().
+		HintAction(a.MustGetProfileNames).
+		StringVar(&input.ProfileName)
+
+	cmd.Flag("mfa-token", "The MFA token to use").
+		StringVar(&input.MfaToken)
+
+	cmd.Flag("no-session", "Use root credentials, do not create a session").
+		BoolVar(&input.NoSession)
+
+	cmd.Action(func(c *kingpin.ParseContext) (err error) {
+		k, err := a.Keyring()
+		if err != nil {
+			return err
+		}
+
+		awsConfigFile, err := a.AwsConfigFile()
+		if err != nil {
+			return err
+		}
+
+		err = CredentialProcessCommand(input, k, awsConfigFile, a.PromptDriver(true))
+		app.FatalIfError(err, "credential-process")
+		return nil
+	})
+}
+
+func CredentialProcessCommand(input CredentialProcessCommandInput, k keyring.Keyring, awsConfigFile *vault.ConfigFile, promptDriver string) error {
+	credentialKeyring := &vault.CredentialKeyring{Keyring: k}
+	oidcTokenKeyring := &vault.OIDCTokenKeyring{Keyring: k}
+	sessionKeyring := &vault.SessionKeyring{Keyring: k}
+
+	profileConfig, err := awsConfigFile.ProfileSection(input.ProfileName)
+	if err != nil {
+		return fmt.Errorf("failed to find profile %q: %w", input.ProfileName, err)
+	}
+
+	provider, err := vault.NewTempCredentialsProvider(profileConfig, credentialKeyring, oidcTokenKeyring, sessionKeyring, vault.VaultOptions{
+		MfaToken:        input.MfaToken,
+		MfaPromptMethod: promptDriver,
+		NoSession:       input.NoSession,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get credentials for %q: %w", input.ProfileName, err)
+	}
+
+	creds, err := provider.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get credentials for %q: %w", input.ProfileName, err)
+	}
+
+	output := credentialProcessOutput{
+		Version:         1,
+		AccessKeyId:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+	}
+	if !creds.Expiration.IsZero() {
+		output.Expiration = creds.Expiration.Format(time.RFC3339)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	return enc.Encode(output)
+}
@@ -5,7 +5,7 @@ import (
 	"io"
 	"log"
 	"os"
-	"regexp"
+	"path/filepath"
 	"strings"
 
 	"github.com/99designs/aws-vault/v7/prompt"
@@ -39,12 +39,76 @@ type AwsVault struct {
 
 var accessControlOptions = []string{"UserPresence", "BiometryCurrentSet", "BiometryAnySet", "DevicePasscode", "Watch", "ApplicationPassword"}
 var accessConstraintOptions = []string{"", "AccessibleWhenUnlocked", "AccessibleAfterFirstUnlock", "AccessibleAfterFirstUnlockThisDeviceOnly", "AccessibleWhenPasscodeSetThisDeviceOnly", "AccessibleWhenUnlockedThisDeviceOnly"}
+var keyCtlScopeOptions = []string{"user", "session", "process", "thread"}
 
 func isATerminal() bool {
 	fd := os.Stdout.Fd()
 	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
 }
 
+func isStdinATerminal() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// expandHomeDir expands a leading "~/" in path to the current user's home directory.
+// Go does not do this itself, unlike a shell, so any flag default or user-supplied path
+// that uses "~/" needs to be passed through this before being handed to the filesystem.
+func expandHomeDir(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, path[2:]), nil
+}
+
+// Option configures an AwsVault built with NewAwsVault, for programs that want to
+// reuse aws-vault's keyring plumbing as a library without running kingpin.
+type Option func(*AwsVault)
+
+// WithKeyringBackend selects a specific keyring backend, equivalent to --backend.
+func WithKeyringBackend(backend string) Option {
+	return func(a *AwsVault) {
+		a.KeyringBackend = backend
+	}
+}
+
+// WithKeyringConfig overrides the default keyring.Config used to open the keyring.
+func WithKeyringConfig(config keyring.Config) Option {
+	return func(a *AwsVault) {
+		a.KeyringConfig = config
+	}
+}
+
+// WithDebug enables debug logging, equivalent to --debug.
+func WithDebug(debug bool) Option {
+	return func(a *AwsVault) {
+		a.Debug = debug
+	}
+}
+
+// NewAwsVault builds a configured AwsVault without requiring a kingpin.Application, so
+// other Go programs can use aws-vault's keyring plumbing (e.g. to call AvailableBackends
+// or Keyring) without recreating the CLI. Unlike ConfigureGlobals, it does not touch any
+// process-wide state (log output, keyring.Debug): it's just a constructor, so embedding
+// programs keep control of their own logging.
+func NewAwsVault(opts ...Option) *AwsVault {
+	a := &AwsVault{
+		KeyringConfig: keyringConfigDefaults,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
 func (a *AwsVault) PromptDriver(avoidTerminalPrompt bool) string {
 	if a.promptDriver == "" {
 		a.promptDriver = "terminal"
@@ -66,6 +130,15 @@ func (a *AwsVault) PromptDriver(avoidTerminalPrompt bool) string {
 
 func (a *AwsVault) Keyring() (keyring.Keyring, error) {
 	if a.keyringImpl == nil {
+		if pureKeychainBackendName != "" && a.KeyringBackend == pureKeychainBackendName {
+			var err error
+			a.keyringImpl, err = vault.NewPureKeychainKeyring(a.KeyringConfig.KeychainName)
+			if err != nil {
+				return nil, err
+			}
+			return a.keyringImpl, nil
+		}
+
 		if a.KeyringBackend != "" {
 			a.KeyringConfig.AllowedBackends = []keyring.BackendType{keyring.BackendType(a.KeyringBackend)}
 		}
@@ -105,13 +178,14 @@ func (a *AwsVault) AvailableBackends() []string {
 	for _, backendType := range keyring.AvailableBackends() {
 		backendsAvailable = append(backendsAvailable, string(backendType))
 	}
+	if pureKeychainBackendName != "" {
+		backendsAvailable = append(backendsAvailable, pureKeychainBackendName)
+	}
 	return backendsAvailable
 }
 
 func ConfigureGlobals(app *kingpin.Application) *AwsVault {
-	a := &AwsVault{
-		KeyringConfig: keyringConfigDefaults,
-	}
+	a := NewAwsVault()
 
 	backendsAvailable := a.AvailableBackends()
 	promptsAvailable := prompt.Available()
@@ -172,6 +246,16 @@ func ConfigureGlobals(app *kingpin.Application) *AwsVault {
 		Envar("AWS_VAULT_FILE_DIR").
 		StringVar(&a.KeyringConfig.FileDir)
 
+	app.Flag("keyctl-scope", fmt.Sprintf("Scope of the kernel keyring to use with the \"keyctl\" backend %v", keyCtlScopeOptions)).
+		Default("session").
+		Envar("AWS_VAULT_KEYCTL_SCOPE").
+		EnumVar(&a.KeyringConfig.KeyCtlScope, keyCtlScopeOptions...)
+
+	app.Flag("keyctl-perm", "Permission mask to apply to keys created in the \"keyctl\" backend").
+		Default("0x3f1f0000").
+		Envar("AWS_VAULT_KEYCTL_PERM").
+		Uint32Var(&a.KeyringConfig.KeyCtlPerm)
+
 	app.Flag("access-control", "Access Control Settings for the Data Protection Keychain \"dp-keychain\" backend").
 		Default("UserPresence").
 		Envar("AWS_VAULT_ACCESS_CONTROL").
@@ -222,32 +306,7 @@ func ConfigureGlobals(app *kingpin.Application) *AwsVault {
 }
 
 func validateAccessControls(a *AwsVault) ([]string, error) {
-	validTerms := accessControlOptions
-	validTermsPattern := strings.Join(validTerms, "|")
-
-	// Regex for checking structure
-	pattern := fmt.Sprintf(`^(%s)(?:\s*(And|Or)\s*(%s))*$`, validTermsPattern, validTermsPattern)
-	regex := regexp.MustCompile(pattern)
-
-	if !regex.MatchString(a.accessControl) {
-		return nil, fmt.Errorf("invalid access control setting: '%s'", a.accessControl)
-	}
-
-	// Split the string by 'And' or 'Or' to check for repeats
-	splitRegex := regexp.MustCompile(`\s*(And|Or)\s*`)
-	terms := splitRegex.Split(a.accessControl, -1)
-
-	// Map to track occurrences of terms
-	seen := make(map[string]bool)
-	for _, term := range terms {
-		normalizedTerm := strings.TrimSpace(term)
-		if seen[normalizedTerm] {
-			return nil, fmt.Errorf("repeated access control term: '%s'", normalizedTerm)
-		}
-		seen[normalizedTerm] = true
-	}
-
-	return terms, nil
+	return vault.ValidateAccessControls(a.accessControl, accessControlOptions)
 }
 
 func StringInSlice(str string, list []string) bool {
@@ -264,6 +323,21 @@ func fileKeyringPassphrasePrompt(prompt string) (string, error) {
 		return password, nil
 	}
 
+	return readPassphraseFromTerminal(prompt, "AWS_VAULT_FILE_PASSPHRASE must be set when running without a terminal")
+}
+
+// readPassphraseFromTerminal prompts on stderr and reads a passphrase from stdin,
+// without the AWS_VAULT_FILE_PASSPHRASE shortcut fileKeyringPassphrasePrompt has. It's
+// used where a fresh, user-entered passphrase is required even if that envar is set,
+// such as when rekeying the file backend. nonInteractiveHint is returned in the error
+// if stdin isn't a terminal, since what a caller should do about that differs: the
+// existing passphrase can come from AWS_VAULT_FILE_PASSPHRASE, but there's no envar
+// equivalent for entering a brand new one.
+func readPassphraseFromTerminal(prompt string, nonInteractiveHint string) (string, error) {
+	if !isStdinATerminal() {
+		return "", fmt.Errorf("%s", nonInteractiveHint)
+	}
+
 	fmt.Fprintf(os.Stderr, "%s: ", prompt)
 	b, err := term.ReadPassword(int(os.Stdin.Fd()))
 	if err != nil {
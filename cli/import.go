@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/99designs/aws-vault/v7/vault"
+	"github.com/99designs/keyring"
+	"github.com/alecthomas/kingpin/v2"
+	"gopkg.in/ini.v1"
+)
+
+type ImportCommandInput struct {
+	ProfileName string
+	SourceFile  string
+	FromEnv     bool
+	FromStdin   bool
+	Prune       bool
+}
+
+func ConfigureImportCommand(app *kingpin.Application, a *AwsVault) {
+	input := ImportCommandInput{}
+
+	cmd := app.Command("import", "Import credentials from another credential store into the selected backend")
+
+	cmd.Arg("profile", "Name of the profile to import credentials into").
+		Required().
+		StringVar(&input.ProfileName)
+
+	cmd.Flag("from-file", "Path to a shared credentials ini file to import from").
+		Default("~/.aws/credentials").
+		StringVar(&input.SourceFile)
+
+	cmd.Flag("from-env", "Import credentials from the AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY/AWS_SESSION_TOKEN environment variables").
+		BoolVar(&input.FromEnv)
+
+	cmd.Flag("from-stdin", "Import a credential_process JSON payload read from stdin").
+		BoolVar(&input.FromStdin)
+
+	cmd.Flag("prune", "Remove the imported profile from the source ini file once the import succeeds").
+		BoolVar(&input.Prune)
+
+	cmd.Action(func(c *kingpin.ParseContext) (err error) {
+		if input.Prune && (input.FromEnv || input.FromStdin) {
+			return fmt.Errorf("--prune is only supported with --from-file")
+		}
+
+		k, err := a.Keyring()
+		if err != nil {
+			return err
+		}
+
+		err = ImportCommand(input, k)
+		app.FatalIfError(err, "import")
+		return nil
+	})
+}
+
+func ImportCommand(input ImportCommandInput, k keyring.Keyring) error {
+	creds, err := readImportCredentials(input)
+	if err != nil {
+		return err
+	}
+
+	credentialKeyring := &vault.CredentialKeyring{Keyring: k}
+
+	if err := credentialKeyring.Set(input.ProfileName, creds); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported credentials for profile %q\n", input.ProfileName)
+
+	if input.Prune {
+		if err := pruneIniSection(input.SourceFile, input.ProfileName); err != nil {
+			return fmt.Errorf("failed to prune %q from %s: %w", input.ProfileName, input.SourceFile, err)
+		}
+		fmt.Printf("Removed [%s] from %s\n", input.ProfileName, input.SourceFile)
+	}
+
+	return nil
+}
+
+func readImportCredentials(input ImportCommandInput) (vault.Credentials, error) {
+	switch {
+	case input.FromStdin:
+		return readCredentialsFromStdin()
+	case input.FromEnv:
+		return readCredentialsFromEnv()
+	default:
+		return readCredentialsFromIniFile(input.SourceFile, input.ProfileName)
+	}
+}
+
+func readCredentialsFromIniFile(path string, profileName string) (vault.Credentials, error) {
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return vault.Credentials{}, err
+	}
+
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return vault.Credentials{}, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+
+	section, err := cfg.GetSection(profileName)
+	if err != nil {
+		return vault.Credentials{}, fmt.Errorf("profile %q not found in %s", profileName, path)
+	}
+
+	accessKeyID := section.Key("aws_access_key_id").String()
+	secretAccessKey := section.Key("aws_secret_access_key").String()
+	if accessKeyID == "" || secretAccessKey == "" {
+		return vault.Credentials{}, fmt.Errorf("profile %q in %s is missing aws_access_key_id/aws_secret_access_key", profileName, path)
+	}
+
+	return vault.Credentials{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    section.Key("aws_session_token").String(),
+	}, nil
+}
+
+func readCredentialsFromEnv() (vault.Credentials, error) {
+	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKeyID == "" || secretAccessKey == "" {
+		return vault.Credentials{}, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must both be set")
+	}
+
+	return vault.Credentials{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}, nil
+}
+
+// readCredentialsFromStdin accepts the same JSON schema that CredentialProcessCommand emits,
+// so output from another tool's credential_process helper can be piped straight in.
+func readCredentialsFromStdin() (vault.Credentials, error) {
+	return parseCredentialProcessJSON(os.Stdin)
+}
+
+func parseCredentialProcessJSON(r io.Reader) (vault.Credentials, error) {
+	var payload credentialProcessOutput
+
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return vault.Credentials{}, fmt.Errorf("failed to parse credential_process JSON from stdin: %w", err)
+	}
+
+	return vault.Credentials{
+		AccessKeyID:     payload.AccessKeyId,
+		SecretAccessKey: payload.SecretAccessKey,
+		SessionToken:    payload.SessionToken,
+	}, nil
+}
+
+func pruneIniSection(path string, profileName string) error {
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return err
+	}
+
+	cfg.DeleteSection(profileName)
+
+	return cfg.SaveTo(path)
+}
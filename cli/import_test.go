@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadCredentialsFromIniFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials")
+
+	contents := "[work]\n" +
+		"aws_access_key_id = AKIAEXAMPLE\n" +
+		"aws_secret_access_key = secretexample\n" +
+		"aws_session_token = tokenexample\n" +
+		"\n" +
+		"[incomplete]\n" +
+		"aws_access_key_id = AKIAEXAMPLE\n"
+
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := readCredentialsFromIniFile(path, "work")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.AccessKeyID != "AKIAEXAMPLE" || creds.SecretAccessKey != "secretexample" || creds.SessionToken != "tokenexample" {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+
+	if _, err := readCredentialsFromIniFile(path, "missing"); err == nil {
+		t.Fatal("expected an error for a profile that doesn't exist")
+	}
+
+	if _, err := readCredentialsFromIniFile(path, "incomplete"); err == nil {
+		t.Fatal("expected an error for a profile missing aws_secret_access_key")
+	}
+}
+
+func TestReadCredentialsFromEnv(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secretexample")
+	t.Setenv("AWS_SESSION_TOKEN", "tokenexample")
+
+	creds, err := readCredentialsFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.AccessKeyID != "AKIAEXAMPLE" || creds.SecretAccessKey != "secretexample" || creds.SessionToken != "tokenexample" {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	if _, err := readCredentialsFromEnv(); err == nil {
+		t.Fatal("expected an error when AWS_SECRET_ACCESS_KEY is unset")
+	}
+}
+
+func TestParseCredentialProcessJSON(t *testing.T) {
+	payload := `{"Version":1,"AccessKeyId":"AKIAEXAMPLE","SecretAccessKey":"secretexample","SessionToken":"tokenexample","Expiration":"2026-01-01T00:00:00Z"}`
+
+	creds, err := parseCredentialProcessJSON(strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.AccessKeyID != "AKIAEXAMPLE" || creds.SecretAccessKey != "secretexample" || creds.SessionToken != "tokenexample" {
+		t.Fatalf("unexpected credentials: %+v", creds)
+	}
+
+	if _, err := parseCredentialProcessJSON(strings.NewReader("not json")); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/99designs/aws-vault/v7/vault"
+	"github.com/99designs/keyring"
+	"github.com/alecthomas/kingpin/v2"
+)
+
+func ConfigureRekeyCommand(app *kingpin.Application, a *AwsVault) {
+	cmd := app.Command("rekey", "Change the passphrase used to encrypt the \"file\" backend")
+
+	cmd.Action(func(c *kingpin.ParseContext) (err error) {
+		if a.KeyringBackend != "" && a.KeyringBackend != string(keyring.FileBackend) {
+			return fmt.Errorf("rekey is only supported with --backend=%s", keyring.FileBackend)
+		}
+
+		err = RekeyCommand(a.KeyringConfig)
+		app.FatalIfError(err, "rekey")
+		return nil
+	})
+}
+
+// RekeyCommand re-encrypts every item in the "file" backend with a new passphrase. The
+// new store is built in a temporary directory alongside the existing one and only
+// swapped into place once every item has been copied across, so a failure partway
+// through leaves the original store untouched.
+func RekeyCommand(config keyring.Config) error {
+	config.AllowedBackends = []keyring.BackendType{keyring.FileBackend}
+
+	fileDir, err := expandFileDir(config.FileDir)
+	if err != nil {
+		return err
+	}
+
+	srcKeyring, err := keyring.Open(config)
+	if err != nil {
+		return err
+	}
+
+	srcCredentialKeyring := &vault.CredentialKeyring{Keyring: srcKeyring}
+	srcOidcTokenKeyring := &vault.OIDCTokenKeyring{Keyring: srcCredentialKeyring.Keyring}
+	srcSessionKeyring := &vault.SessionKeyring{Keyring: srcCredentialKeyring.Keyring}
+
+	credentialNames, err := srcCredentialKeyring.Keys()
+	if err != nil {
+		return err
+	}
+
+	oidcTokenNames, err := srcOidcTokenKeyring.Keys()
+	if err != nil {
+		return err
+	}
+
+	sessionNames, err := srcSessionKeyring.Keys()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Found %d credentials to rekey", len(credentialNames))
+	log.Printf("Found %d OIDC tokens to rekey", len(oidcTokenNames))
+	log.Printf("Found %d sessions to rekey", len(sessionNames))
+
+	const nonInteractiveHint = "rekey requires an interactive terminal to enter a new passphrase"
+
+	newPassphrase, err := readPassphraseFromTerminal("Enter the new passphrase", nonInteractiveHint)
+	if err != nil {
+		return err
+	}
+
+	confirmPassphrase, err := readPassphraseFromTerminal("Confirm the new passphrase", nonInteractiveHint)
+	if err != nil {
+		return err
+	}
+
+	if newPassphrase != confirmPassphrase {
+		return fmt.Errorf("passphrases do not match")
+	}
+
+	tmpDir, err := os.MkdirTemp(filepath.Dir(fileDir), ".aws-vault-rekey-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	destConfig := config
+	destConfig.FileDir = tmpDir
+	destConfig.FilePasswordFunc = func(string) (string, error) { return newPassphrase, nil }
+
+	destKeyring, err := keyring.Open(destConfig)
+	if err != nil {
+		return err
+	}
+
+	destCredentialKeyring := &vault.CredentialKeyring{Keyring: destKeyring}
+	destOidcTokenKeyring := &vault.OIDCTokenKeyring{Keyring: destCredentialKeyring.Keyring}
+	destSessionKeyring := &vault.SessionKeyring{Keyring: destCredentialKeyring.Keyring}
+
+	for _, name := range credentialNames {
+		creds, err := srcCredentialKeyring.Get(name)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Rekeying %s", name)
+
+		if err := destCredentialKeyring.Set(name, creds); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range oidcTokenNames {
+		token, err := srcOidcTokenKeyring.Get(name)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Rekeying %s", name)
+
+		if err := destOidcTokenKeyring.Set(name, token); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sessionNames {
+		session, err := srcSessionKeyring.Get(name)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Rekeying %s", name)
+
+		if err := destSessionKeyring.Set(name, session); err != nil {
+			return err
+		}
+	}
+
+	if err := syncDir(tmpDir); err != nil {
+		return err
+	}
+
+	backupDir := fileDir + ".bak"
+	if err := os.RemoveAll(backupDir); err != nil {
+		return err
+	}
+	if err := os.Rename(fileDir, backupDir); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpDir, fileDir); err != nil {
+		if rollbackErr := os.Rename(backupDir, fileDir); rollbackErr != nil {
+			return fmt.Errorf("failed to install rekeyed store (%s) and failed to roll back (%s)", err, rollbackErr)
+		}
+		return fmt.Errorf("failed to install rekeyed store, rolled back to the original: %w", err)
+	}
+	os.RemoveAll(backupDir)
+
+	fmt.Printf("Rekeyed %d credentials, %d OIDC tokens, and %d sessions.\n", len(credentialNames), len(oidcTokenNames), len(sessionNames))
+
+	return nil
+}
+
+func expandFileDir(dir string) (string, error) {
+	if dir == "" {
+		dir = "~/.awsvault/keys/"
+	}
+
+	dir, err := expandHomeDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Clean(dir), nil
+}
+
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return f.Sync()
+}
@@ -0,0 +1,39 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidateAccessControls checks that accessControl is one of validTerms, or a combination
+// of validTerms joined by "And"/"Or" with no term repeated, and returns the individual terms.
+// It is exported so that tools embedding aws-vault's keyring plumbing can validate an
+// --access-control style setting without depending on the cli package.
+func ValidateAccessControls(accessControl string, validTerms []string) ([]string, error) {
+	validTermsPattern := strings.Join(validTerms, "|")
+
+	// Regex for checking structure
+	pattern := fmt.Sprintf(`^(%s)(?:\s*(And|Or)\s*(%s))*$`, validTermsPattern, validTermsPattern)
+	regex := regexp.MustCompile(pattern)
+
+	if !regex.MatchString(accessControl) {
+		return nil, fmt.Errorf("invalid access control setting: '%s'", accessControl)
+	}
+
+	// Split the string by 'And' or 'Or' to check for repeats
+	splitRegex := regexp.MustCompile(`\s*(And|Or)\s*`)
+	terms := splitRegex.Split(accessControl, -1)
+
+	// Map to track occurrences of terms
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		normalizedTerm := strings.TrimSpace(term)
+		if seen[normalizedTerm] {
+			return nil, fmt.Errorf("repeated access control term: '%s'", normalizedTerm)
+		}
+		seen[normalizedTerm] = true
+	}
+
+	return terms, nil
+}
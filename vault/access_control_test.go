@@ -0,0 +1,44 @@
+package vault
+
+import "testing"
+
+func TestValidateAccessControls(t *testing.T) {
+	validTerms := []string{"UserPresence", "BiometryCurrentSet", "BiometryAnySet", "DevicePasscode", "Watch", "ApplicationPassword"}
+
+	cases := []struct {
+		name          string
+		accessControl string
+		want          []string
+		wantErr       bool
+	}{
+		{"SingleTerm", "UserPresence", []string{"UserPresence"}, false},
+		{"AndCombination", "UserPresenceAndBiometryAnySet", []string{"UserPresence", "BiometryAnySet"}, false},
+		{"OrCombination", "UserPresenceOrWatch", []string{"UserPresence", "Watch"}, false},
+		{"InvalidTerm", "UserPresenceAndInvalid", nil, true},
+		{"ConjunctionAtStart", "AndUserPresence", nil, true},
+		{"InvalidCasing", "userpresence", nil, true},
+		{"InvalidConjunctions", "UserPresence,Watch", nil, true},
+		{"RepeatTerms", "UserPresenceAndUserPresence", nil, true},
+		{"RepeatConjunctions", "UserPresenceAndAndWatch", nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ValidateAccessControls(tc.accessControl, validTerms)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateAccessControls(%q) error = %v, wantErr %v", tc.accessControl, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("ValidateAccessControls(%q) = %v, want %v", tc.accessControl, got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("ValidateAccessControls(%q) = %v, want %v", tc.accessControl, got, tc.want)
+				}
+			}
+		})
+	}
+}
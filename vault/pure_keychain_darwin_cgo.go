@@ -0,0 +1,96 @@
+//go:build darwin && cgo
+
+package vault
+
+import (
+	"fmt"
+
+	"github.com/99designs/keyring"
+	gokeychain "github.com/keybase/go-keychain"
+)
+
+// pureKeychainKeyring is a keyring.Keyring backed by the macOS keychain via the native
+// Security framework. It exists alongside the CGO-free variant in
+// pure_keychain_darwin_nocgo.go so that "pure-keychain" behaves identically whether or
+// not the binary was cross-compiled with CGO disabled.
+type pureKeychainKeyring struct {
+	keychainName string
+}
+
+// NewPureKeychainKeyring returns a keyring.Keyring that stores items as generic passwords
+// in the user's default macOS keychain, with keychainName as their service. It does not
+// open or create a separate keychain file named keychainName.
+func NewPureKeychainKeyring(keychainName string) (keyring.Keyring, error) {
+	return &pureKeychainKeyring{keychainName: keychainName}, nil
+}
+
+func (k *pureKeychainKeyring) Get(key string) (keyring.Item, error) {
+	query := gokeychain.NewItem()
+	query.SetSecClass(gokeychain.SecClassGenericPassword)
+	query.SetService(k.keychainName)
+	query.SetAccount(key)
+	query.SetMatchLimit(gokeychain.MatchLimitOne)
+	query.SetReturnData(true)
+
+	results, err := gokeychain.QueryItem(query)
+	if err != nil {
+		return keyring.Item{}, err
+	}
+	if len(results) == 0 {
+		return keyring.Item{}, keyring.ErrKeyNotFound
+	}
+
+	return keyring.Item{Key: key, Data: results[0].Data}, nil
+}
+
+func (k *pureKeychainKeyring) GetMetadata(key string) (keyring.Metadata, error) {
+	return keyring.Metadata{}, fmt.Errorf("GetMetadata is not supported by the pure-keychain backend")
+}
+
+func (k *pureKeychainKeyring) Set(item keyring.Item) error {
+	_ = k.Remove(item.Key)
+
+	entry := gokeychain.NewItem()
+	entry.SetSecClass(gokeychain.SecClassGenericPassword)
+	entry.SetService(k.keychainName)
+	entry.SetAccount(item.Key)
+	entry.SetLabel(item.Label)
+	entry.SetData(item.Data)
+	entry.SetSynchronizable(gokeychain.SynchronizableNo)
+	entry.SetAccessible(gokeychain.AccessibleWhenUnlocked)
+
+	return gokeychain.AddItem(entry)
+}
+
+func (k *pureKeychainKeyring) Remove(key string) error {
+	item := gokeychain.NewItem()
+	item.SetSecClass(gokeychain.SecClassGenericPassword)
+	item.SetService(k.keychainName)
+	item.SetAccount(key)
+
+	err := gokeychain.DeleteItem(item)
+	if err == gokeychain.ErrorItemNotFound {
+		return keyring.ErrKeyNotFound
+	}
+	return err
+}
+
+func (k *pureKeychainKeyring) Keys() ([]string, error) {
+	query := gokeychain.NewItem()
+	query.SetSecClass(gokeychain.SecClassGenericPassword)
+	query.SetService(k.keychainName)
+	query.SetMatchLimit(gokeychain.MatchLimitAll)
+	query.SetReturnAttributes(true)
+
+	results, err := gokeychain.QueryItem(query)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(results))
+	for _, r := range results {
+		keys = append(keys, r.Account)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,144 @@
+//go:build darwin && !cgo
+
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/99designs/keyring"
+)
+
+// pureKeychainKeyring is a CGO-free keyring.Keyring that shells out to the `security`
+// CLI. It's used when CGO is disabled, which lets aws-vault be cross-compiled for
+// macOS from Linux CI for reproducible release builds.
+type pureKeychainKeyring struct {
+	keychainName string
+}
+
+// NewPureKeychainKeyring returns a keyring.Keyring that stores items as generic passwords
+// in the user's default macOS keychain, with keychainName as their service, implemented
+// via the `security` command line tool rather than CGO. It does not open or create a
+// separate keychain file named keychainName.
+func NewPureKeychainKeyring(keychainName string) (keyring.Keyring, error) {
+	return &pureKeychainKeyring{keychainName: keychainName}, nil
+}
+
+const securityBin = "/usr/bin/security"
+
+func (k *pureKeychainKeyring) Get(key string) (keyring.Item, error) {
+	out, err := exec.Command(securityBin, "find-generic-password",
+		"-s", k.keychainName, "-a", key, "-w").Output()
+	if err != nil {
+		if isSecurityItemNotFound(err) {
+			return keyring.Item{}, keyring.ErrKeyNotFound
+		}
+		return keyring.Item{}, err
+	}
+
+	return keyring.Item{Key: key, Data: bytes.TrimRight(out, "\n")}, nil
+}
+
+func (k *pureKeychainKeyring) GetMetadata(key string) (keyring.Metadata, error) {
+	return keyring.Metadata{}, fmt.Errorf("GetMetadata is not supported by the pure-keychain backend")
+}
+
+// Set shells out to `add-generic-password`. NOTE: unlike the CGO implementation, which
+// calls the Security framework directly, this passes the secret as a `-w` argument on
+// the `security` command line, so for the duration of the call it is visible to any
+// local user who can read the process table (e.g. via `ps`/`/proc`). The `security` CLI
+// has no stdin-based way to set a generic password, so this exposure is unavoidable
+// when CGO is disabled; callers who can't accept it should use the CGO build.
+func (k *pureKeychainKeyring) Set(item keyring.Item) error {
+	_ = k.Remove(item.Key)
+
+	args := []string{"add-generic-password",
+		"-s", k.keychainName, "-a", item.Key, "-w", string(item.Data), "-U"}
+	if item.Label != "" {
+		args = append(args, "-l", item.Label)
+	}
+
+	return exec.Command(securityBin, args...).Run()
+}
+
+func (k *pureKeychainKeyring) Remove(key string) error {
+	cmd := exec.Command(securityBin, "delete-generic-password",
+		"-s", k.keychainName, "-a", key)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	if err != nil && isSecurityItemNotFound(err) {
+		return keyring.ErrKeyNotFound
+	}
+	return err
+}
+
+func (k *pureKeychainKeyring) Keys() ([]string, error) {
+	out, err := exec.Command(securityBin, "dump-keychain").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, record := range splitSecurityRecords(string(out)) {
+		var account, service string
+		for _, line := range strings.Split(record, "\n") {
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, `"acct"`):
+				account = parseSecurityAttribute(line)
+			case strings.HasPrefix(line, `"svce"`):
+				service = parseSecurityAttribute(line)
+			}
+		}
+		if service == k.keychainName && account != "" {
+			keys = append(keys, account)
+		}
+	}
+
+	return keys, nil
+}
+
+// splitSecurityRecords splits `security dump-keychain` output into one chunk per item.
+// Attributes within a record are printed alphabetically (so "acct" precedes "svce"),
+// so the whole record must be buffered before deciding which item's account it holds.
+func splitSecurityRecords(out string) []string {
+	var records []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "keychain:") && current.Len() > 0 {
+			records = append(records, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+	if current.Len() > 0 {
+		records = append(records, current.String())
+	}
+
+	return records
+}
+
+func parseSecurityAttribute(line string) string {
+	parts := strings.SplitN(line, "=", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(parts[1]), `"`)
+}
+
+func isSecurityItemNotFound(err error) bool {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return bytes.Contains(exitErr.Stderr, []byte("could not be found"))
+	}
+	return false
+}
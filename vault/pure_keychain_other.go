@@ -0,0 +1,15 @@
+//go:build !darwin
+
+package vault
+
+import (
+	"fmt"
+
+	"github.com/99designs/keyring"
+)
+
+// NewPureKeychainKeyring is only supported on darwin; see pure_keychain_darwin_cgo.go
+// and pure_keychain_darwin_nocgo.go for the real implementations.
+func NewPureKeychainKeyring(keychainName string) (keyring.Keyring, error) {
+	return nil, fmt.Errorf("the pure-keychain backend is only supported on macOS")
+}